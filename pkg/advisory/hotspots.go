@@ -0,0 +1,223 @@
+package advisory
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+)
+
+// PackageActivity summarizes the changes made to a package's advisory data
+// over a range of the advisories repository's git history.
+type PackageActivity struct {
+	// Package is the name of the package.
+	Package string
+
+	// Commits is the number of commits that changed the package's advisories.
+	Commits int
+
+	// AdvisoriesAdded is the number of times an advisory was added.
+	AdvisoriesAdded int
+
+	// AdvisoriesModified is the number of times an existing advisory was changed,
+	// such as by adding an event as the vulnerability's status evolves.
+	AdvisoriesModified int
+
+	// AdvisoriesRemoved is the number of times an advisory was removed.
+	AdvisoriesRemoved int
+}
+
+// Changes returns the total number of advisory changes for the package.
+func (a PackageActivity) Changes() int {
+	return a.AdvisoriesAdded + a.AdvisoriesModified + a.AdvisoriesRemoved
+}
+
+// HotspotsOptions configures the Hotspots function.
+type HotspotsOptions struct {
+	// Repository is the git repository of the advisories data.
+	Repository *git.Repository
+
+	// From is the commit where the range of history begins, exclusive, as in
+	// "git log From..To". If zero, the range includes all of To's history.
+	From plumbing.Hash
+
+	// To is the commit where the range of history ends, inclusive.
+	To plumbing.Hash
+}
+
+// Hotspots walks the range of the advisories repository's git history and
+// returns the advisory activity for each package whose advisories were changed
+// in the range, ordered from most to least changes.
+//
+// Each commit is compared to its parent using the same diffing as IndexDiff.
+// Merge commits are skipped, since their changes are counted in the commits
+// being merged. Advisory files that can't be decoded as v2 documents (such as
+// those from before the v2 schema) are skipped.
+func Hotspots(opts HotspotsOptions) ([]PackageActivity, error) {
+	excluded := make(map[plumbing.Hash]struct{})
+	if !opts.From.IsZero() {
+		iter, err := opts.Repository.Log(&git.LogOptions{From: opts.From})
+		if err != nil {
+			return nil, fmt.Errorf("walking history of %s: %w", opts.From, err)
+		}
+
+		err = iter.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walking history of %s: %w", opts.From, err)
+		}
+	}
+
+	iter, err := opts.Repository.Log(&git.LogOptions{From: opts.To})
+	if err != nil {
+		return nil, fmt.Errorf("walking history of %s: %w", opts.To, err)
+	}
+
+	activityByPackage := make(map[string]*PackageActivity)
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		if _, ok := excluded[c.Hash]; ok {
+			// This commit is an ancestor of From, and so is outside the range.
+			return nil
+		}
+
+		if c.NumParents() > 1 {
+			return nil
+		}
+
+		diffs, err := commitDocumentDiffs(c)
+		if err != nil {
+			return fmt.Errorf("diffing commit %s: %w", c.Hash, err)
+		}
+
+		for _, diff := range diffs {
+			a, ok := activityByPackage[diff.Name]
+			if !ok {
+				a = &PackageActivity{Package: diff.Name}
+				activityByPackage[diff.Name] = a
+			}
+
+			a.Commits++
+			a.AdvisoriesAdded += len(diff.Added)
+			a.AdvisoriesModified += len(diff.Modified)
+			a.AdvisoriesRemoved += len(diff.Removed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activity := make([]PackageActivity, 0, len(activityByPackage))
+	for _, a := range activityByPackage {
+		activity = append(activity, *a)
+	}
+
+	slices.SortFunc(activity, func(a, b PackageActivity) int {
+		if c := cmp.Compare(b.Changes(), a.Changes()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Package, b.Package)
+	})
+
+	return activity, nil
+}
+
+// commitDocumentDiffs returns the non-empty diffs of the advisory documents
+// changed by the commit, compared to its first parent.
+func commitDocumentDiffs(c *object.Commit) ([]DocumentDiffResult, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	parentTree := &object.Tree{}
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []DocumentDiffResult
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		if !strings.HasSuffix(path.Base(name), ".advisories.yaml") {
+			continue
+		}
+
+		from, to, err := change.Files()
+		if err != nil {
+			return nil, err
+		}
+
+		a, errA := decodeDocumentFile(from)
+		b, errB := decodeDocumentFile(to)
+		if errA != nil || errB != nil {
+			continue
+		}
+
+		diff := documentDiff(a, b)
+		if diff.IsZero() {
+			continue
+		}
+
+		diff.Name = cmp.Or(b.Name(), a.Name())
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+// decodeDocumentFile decodes the advisory document in the file. A nil file
+// (i.e. the file doesn't exist on one side of a change) decodes to an empty
+// document.
+func decodeDocumentFile(f *object.File) (v2.Document, error) {
+	if f == nil {
+		return v2.Document{}, nil
+	}
+
+	r, err := f.Reader()
+	if err != nil {
+		return v2.Document{}, err
+	}
+	defer r.Close()
+
+	doc, err := v2.DecodeDocument(r)
+	if err != nil {
+		return v2.Document{}, err
+	}
+
+	if err := doc.ValidateSchemaVersion(); err != nil {
+		return v2.Document{}, err
+	}
+
+	if doc.Name() == "" {
+		return v2.Document{}, errors.New("document has no package name")
+	}
+
+	return *doc, nil
+}
@@ -0,0 +1,218 @@
+package advisory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotspots(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	when := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(files map[string]string) plumbing.Hash {
+		t.Helper()
+
+		for name, content := range files {
+			p := filepath.Join(dir, name)
+			if content == "" {
+				require.NoError(t, os.Remove(p))
+				continue
+			}
+			require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+		}
+
+		_, err := wt.Add(".")
+		require.NoError(t, err)
+
+		when = when.Add(time.Hour)
+		hash, err := wt.Commit("update", &git.CommitOptions{
+			All:    true,
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: when},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	c1 := commit(map[string]string{
+		"README.md": "advisories\n",
+		"glibc.advisories.yaml": `schema-version: "2"
+package:
+  name: glibc
+advisories:
+  - id: CGA-2vrq-8fwc-xrqf
+    aliases:
+      - CVE-2023-4911
+    events:
+      - timestamp: 2023-10-03T12:00:00Z
+        type: detection
+        data:
+          type: manual
+`,
+		"zlib.advisories.yaml": `schema-version: "2"
+package:
+  name: zlib
+advisories:
+  - id: CGA-wqj8-vm8w-pjfr
+    aliases:
+      - CVE-2022-37434
+    events:
+      - timestamp: 2022-08-08T12:00:00Z
+        type: fixed
+        data:
+          fixed-version: 1.2.12-r3
+`,
+	})
+
+	// Add an event to glibc's advisory, and add a second glibc advisory.
+	c2 := commit(map[string]string{
+		"glibc.advisories.yaml": `schema-version: "2"
+package:
+  name: glibc
+advisories:
+  - id: CGA-2vrq-8fwc-xrqf
+    aliases:
+      - CVE-2023-4911
+    events:
+      - timestamp: 2023-10-03T12:00:00Z
+        type: detection
+        data:
+          type: manual
+      - timestamp: 2023-10-04T12:00:00Z
+        type: fixed
+        data:
+          fixed-version: 2.38-r2
+  - id: CGA-6x9q-7c3m-3f4g
+    aliases:
+      - CVE-2023-5156
+    events:
+      - timestamp: 2023-10-05T12:00:00Z
+        type: true-positive-determination
+`,
+	})
+
+	// Change the second glibc advisory, remove zlib's document, and change a
+	// file that isn't advisory data.
+	c3 := commit(map[string]string{
+		"README.md": "advisory data\n",
+		"glibc.advisories.yaml": `schema-version: "2"
+package:
+  name: glibc
+advisories:
+  - id: CGA-2vrq-8fwc-xrqf
+    aliases:
+      - CVE-2023-4911
+    events:
+      - timestamp: 2023-10-03T12:00:00Z
+        type: detection
+        data:
+          type: manual
+      - timestamp: 2023-10-04T12:00:00Z
+        type: fixed
+        data:
+          fixed-version: 2.38-r2
+  - id: CGA-6x9q-7c3m-3f4g
+    aliases:
+      - CVE-2023-5156
+    events:
+      - timestamp: 2023-10-05T12:00:00Z
+        type: true-positive-determination
+      - timestamp: 2023-10-06T12:00:00Z
+        type: fixed
+        data:
+          fixed-version: 2.38-r3
+`,
+		"zlib.advisories.yaml": "",
+	})
+
+	// Advisory data in an older schema, or that wouldn't decode when indexed,
+	// isn't counted.
+	c4 := commit(map[string]string{
+		"crane.advisories.yaml": `schema-version: "2"
+package:
+  name: crane
+advisories:
+  - id: CGA-8g4x-6xj2-4vrm
+    aliases:
+      - CVE-2024-24790
+    severity: high
+    events:
+      - timestamp: 2024-06-05T12:00:00Z
+        type: true-positive-determination
+`,
+		"openssh.advisories.yaml": `schema-version: "1"
+package:
+  name: openssh
+advisories:
+  CVE-2023-38408:
+    - timestamp: 2023-07-20T12:00:00Z
+      status: fixed
+      fixed-version: 9.3_p2-r0
+`,
+	})
+
+	cases := []struct {
+		name     string
+		from, to plumbing.Hash
+		expected []PackageActivity
+	}{
+		{
+			name: "all history",
+			to:   c4,
+			expected: []PackageActivity{
+				{Package: "glibc", Commits: 3, AdvisoriesAdded: 2, AdvisoriesModified: 2},
+				{Package: "zlib", Commits: 2, AdvisoriesAdded: 1, AdvisoriesRemoved: 1},
+			},
+		},
+		{
+			name: "range",
+			from: c1,
+			to:   c3,
+			expected: []PackageActivity{
+				{Package: "glibc", Commits: 2, AdvisoriesAdded: 1, AdvisoriesModified: 2},
+				{Package: "zlib", Commits: 1, AdvisoriesRemoved: 1},
+			},
+		},
+		{
+			name: "single commit",
+			from: c1,
+			to:   c2,
+			expected: []PackageActivity{
+				{Package: "glibc", Commits: 1, AdvisoriesAdded: 1, AdvisoriesModified: 1},
+			},
+		},
+		{
+			name:     "no activity",
+			from:     c3,
+			to:       c4,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Hotspots(HotspotsOptions{
+				Repository: repo,
+				From:       tt.from,
+				To:         tt.to,
+			})
+			require.NoError(t, err)
+
+			if diff := cmp.Diff(tt.expected, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Hotspots() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
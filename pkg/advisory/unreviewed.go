@@ -0,0 +1,33 @@
+package advisory
+
+import (
+	"slices"
+
+	"chainguard.dev/melange/pkg/config"
+	"github.com/wolfi-dev/wolfictl/pkg/configs"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+)
+
+// PackagesWithoutAdvisories returns the names of the packages defined in the
+// given build configs that have no advisories, either because there's no
+// advisory document for the package or because the document is empty. The list
+// is sorted and has no duplicates.
+func PackagesWithoutAdvisories(buildCfgs *configs.Index[config.Configuration], advisoryDocs *configs.Index[v2.Document]) []string {
+	var names []string
+
+	buildCfgs.Select().Each(func(e configs.Entry[config.Configuration]) {
+		name := e.Configuration().Package.Name
+
+		documents := advisoryDocs.Select().WhereName(name).Configurations()
+		for _, doc := range documents {
+			if len(doc.Advisories) > 0 {
+				return
+			}
+		}
+
+		names = append(names, name)
+	})
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
@@ -0,0 +1,28 @@
+package advisory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	"github.com/wolfi-dev/wolfictl/pkg/configs/build"
+	rwos "github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os"
+)
+
+func TestPackagesWithoutAdvisories(t *testing.T) {
+	buildCfgs, err := build.NewIndex(context.Background(), rwos.DirFS("./testdata/unreviewed/distro"))
+	require.NoError(t, err)
+
+	advisoryDocs, err := v2.NewIndex(context.Background(), rwos.DirFS("./testdata/unreviewed/advisories"))
+	require.NoError(t, err)
+
+	// curl has no advisory document, and jq's document has no advisories.
+	expected := []string{"curl", "jq"}
+
+	actual := PackagesWithoutAdvisories(buildCfgs, advisoryDocs)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("PackagesWithoutAdvisories() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -439,8 +439,15 @@ func (opts ValidateOptions) validateIndexDiff(ctx context.Context, diff IndexDif
 				}
 			}
 
+			latest := adv.Removed.Latest()
 			for i, event := range adv.AddedEvents {
-				advErrs = append(advErrs, errorhelpers.LabelError(fmt.Sprintf("event %d (just added)", i+1), opts.validateRecency(event)))
+				advErrs = append(advErrs, errorhelpers.LabelError(
+					fmt.Sprintf("event %d (just added)", i+1),
+					errors.Join(
+						opts.validateAddedEvent(documentAdvisories.Name, event),
+						validateEventNotEarlierThan(event, latest),
+					),
+				))
 			}
 
 			docErrs = append(
@@ -464,7 +471,7 @@ func (opts ValidateOptions) validateIndexDiff(ctx context.Context, diff IndexDif
 
 			var advErrs []error
 			for i, event := range adv.Events {
-				advErrs = append(advErrs, errorhelpers.LabelError(fmt.Sprintf("event %d (just added)", i+1), opts.validateRecency(event)))
+				advErrs = append(advErrs, errorhelpers.LabelError(fmt.Sprintf("event %d (just added)", i+1), opts.validateAddedEvent(documentAdvisories.Name, event)))
 			}
 			docErrs = append(
 				docErrs,
@@ -500,7 +507,7 @@ func (opts ValidateOptions) validateIndexDiff(ctx context.Context, diff IndexDif
 
 			var advErrs []error
 			for i, event := range adv.Events {
-				advErrs = append(advErrs, errorhelpers.LabelError(fmt.Sprintf("event %d (just added)", i+1), opts.validateRecency(event)))
+				advErrs = append(advErrs, errorhelpers.LabelError(fmt.Sprintf("event %d (just added)", i+1), opts.validateAddedEvent(doc.Name(), event)))
 			}
 			docErrs = append(
 				docErrs,
@@ -517,6 +524,65 @@ func (opts ValidateOptions) validateIndexDiff(ctx context.Context, diff IndexDif
 	return errorhelpers.LabelError("invalid change(s) in diff", errors.Join(errs...))
 }
 
+// validateAddedEvent returns an error if an event that was just added to an
+// advisory for the given package is invalid.
+func (opts ValidateOptions) validateAddedEvent(pkgName string, event v2.Event) error {
+	return errors.Join(
+		opts.validateRecency(event),
+		opts.validateSubpackageReference(pkgName, event),
+	)
+}
+
+// validateEventNotEarlierThan returns an error if the event, which was just
+// added to an advisory, has a timestamp earlier than the given event, which is
+// the latest event the advisory already had. Events are added as the
+// investigation of a vulnerability progresses, so an advisory's history should
+// never move backward in time.
+func validateEventNotEarlierThan(event, latest v2.Event) error {
+	if event.Timestamp.Before(latest.Timestamp) {
+		return fmt.Errorf(
+			"event's timestamp (%s) is earlier than the timestamp of the advisory's latest existing event (%s)",
+			event.Timestamp,
+			latest.Timestamp,
+		)
+	}
+	return nil
+}
+
+// validateSubpackageReference returns an error if the event is a scan/v1
+// detection whose subpackage isn't defined by the package's build
+// configuration. If the build configuration isn't available, no validation is
+// performed.
+func (opts ValidateOptions) validateSubpackageReference(pkgName string, event v2.Event) error {
+	detection, ok := event.Data.(v2.Detection)
+	if !ok || detection.Type != v2.DetectionTypeScanV1 {
+		return nil
+	}
+
+	scan, ok := detection.Data.(v2.DetectionScanV1)
+	if !ok {
+		return nil
+	}
+
+	cfg, ok := opts.distroPackageMap[pkgName]
+	if !ok {
+		// Not enough input information to drive this validation check.
+		return nil
+	}
+
+	if scan.SubpackageName == cfg.Package.Name {
+		return nil
+	}
+
+	for i := range cfg.Subpackages {
+		if scan.SubpackageName == cfg.Subpackages[i].Name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("detection's subpackage %q is not defined by the build configuration for package %q", scan.SubpackageName, pkgName)
+}
+
 const eventMaxValidAgeInDays = 3
 
 func (opts ValidateOptions) isRecent(t time.Time) bool {
@@ -2,8 +2,10 @@ package advisory
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"chainguard.dev/apko/pkg/apk/apk"
 	"chainguard.dev/melange/pkg/config"
@@ -12,6 +14,7 @@ import (
 	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
 	"github.com/wolfi-dev/wolfictl/pkg/configs/build"
 	rwos "github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os"
+	"github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os/memfs"
 )
 
 //nolint:gocyclo // Current cyclomatic complexity is 31 and > 30.
@@ -59,6 +62,10 @@ func TestValidate(t *testing.T) {
 				name:          "added-event-with-non-recent-timestamp",
 				shouldBeValid: false,
 			},
+			{
+				name:          "added-event-with-earlier-timestamp",
+				shouldBeValid: false,
+			},
 		}
 
 		for _, tt := range cases {
@@ -179,6 +186,32 @@ func TestValidate(t *testing.T) {
 					apkindex:        &apk.APKIndex{},
 					shouldBeValid:   false,
 				},
+				{
+					name:            "added-event-with-known-subpackage",
+					subcase:         "subpackage in distro",
+					packageCfgsFunc: distroWithKo,
+					apkindex: &apk.APKIndex{
+						Packages: []*apk.Package{
+							{
+								Name: "ko",
+							},
+						},
+					},
+					shouldBeValid: true,
+				},
+				{
+					name:            "added-event-with-unknown-subpackage",
+					subcase:         "subpackage not in distro",
+					packageCfgsFunc: distroWithKo,
+					apkindex: &apk.APKIndex{
+						Packages: []*apk.Package{
+							{
+								Name: "ko",
+							},
+						},
+					},
+					shouldBeValid: false,
+				},
 			}
 
 			for _, tt := range cases {
@@ -210,6 +243,64 @@ func TestValidate(t *testing.T) {
 		})
 	})
 
+	t.Run("update with timestamp", func(t *testing.T) {
+		// The existing event for the advisory in this fixture is at 2023-11-10T12:00:00Z.
+		aDir := filepath.Join("testdata", "diff", "added-event-with-earlier-timestamp", "a")
+
+		cases := []struct {
+			name          string
+			timestamp     time.Time
+			shouldBeValid bool
+		}{
+			{
+				name:          "after the latest existing event",
+				timestamp:     time.Date(2023, time.November, 10, 18, 0, 0, 0, time.UTC),
+				shouldBeValid: true,
+			},
+			{
+				name:          "before the latest existing event",
+				timestamp:     time.Date(2023, time.November, 10, 0, 0, 0, 0, time.UTC),
+				shouldBeValid: false,
+			},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				aIndex, err := v2.NewIndex(context.Background(), rwos.DirFS(aDir))
+				require.NoError(t, err)
+				bIndex, err := v2.NewIndex(context.Background(), memfs.New(os.DirFS(aDir)))
+				require.NoError(t, err)
+
+				err = Update(context.Background(), Request{
+					Package: "ko",
+					Aliases: []string{"CVE-2023-11111"},
+					Event: v2.Event{
+						Timestamp: v2.Timestamp(tt.timestamp),
+						Type:      v2.EventTypeFixed,
+						Data: v2.Fixed{
+							FixedVersion: "0.15.1-r0",
+						},
+					},
+				}, UpdateOptions{
+					AdvisoryDocs: bIndex,
+				})
+				require.NoError(t, err)
+
+				err = Validate(context.Background(), ValidateOptions{
+					AdvisoryDocs:     bIndex,
+					BaseAdvisoryDocs: aIndex,
+					Now:              now,
+				})
+				if tt.shouldBeValid && err != nil {
+					t.Errorf("should be valid but got error: %v", err)
+				}
+				if !tt.shouldBeValid && err == nil {
+					t.Error("shouldn't be valid but got no error")
+				}
+			})
+		}
+	})
+
 	t.Run("alias completeness", func(t *testing.T) {
 		cases := []struct {
 			name          string
@@ -0,0 +1,58 @@
+package advisory
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/wolfi-dev/wolfictl/pkg/configs"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	"github.com/wolfi-dev/wolfictl/pkg/vuln"
+)
+
+// VulnerabilityReference describes a vulnerability ID and the packages whose
+// advisories cite it.
+type VulnerabilityReference struct {
+	// ID is the vulnerability ID, such as a CVE or GHSA ID.
+	ID string
+
+	// Packages is the sorted list of names of packages with an advisory that
+	// cites the vulnerability ID.
+	Packages []string
+}
+
+// ListVulnerabilities returns every vulnerability ID cited by an advisory in the
+// given indices, either as the advisory's ID or as one of its aliases. CGA IDs
+// are advisory IDs rather than vulnerability IDs, so they're not included. The
+// list is sorted by vulnerability ID and has no duplicates.
+func ListVulnerabilities(indices ...*configs.Index[v2.Document]) []VulnerabilityReference {
+	packagesByID := make(map[string][]string)
+
+	for _, index := range indices {
+		for _, doc := range index.Select().Configurations() {
+			for _, adv := range doc.Advisories {
+				for _, id := range adv.VulnerabilityIDs() {
+					if vuln.RegexCGA.MatchString(id) {
+						continue
+					}
+
+					packagesByID[id] = append(packagesByID[id], doc.Name())
+				}
+			}
+		}
+	}
+
+	refs := make([]VulnerabilityReference, 0, len(packagesByID))
+	for id, pkgs := range packagesByID {
+		slices.Sort(pkgs)
+		refs = append(refs, VulnerabilityReference{
+			ID:       id,
+			Packages: slices.Compact(pkgs),
+		})
+	}
+
+	slices.SortFunc(refs, func(a, b VulnerabilityReference) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return refs
+}
@@ -0,0 +1,38 @@
+package advisory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	rwos "github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os"
+)
+
+func TestListVulnerabilities(t *testing.T) {
+	advisoryFsys := rwos.DirFS("./testdata/vulnerabilities/advisories")
+	advisoryDocs, err := v2.NewIndex(context.Background(), advisoryFsys)
+	require.NoError(t, err)
+
+	expected := []VulnerabilityReference{
+		{
+			ID:       "CVE-2023-38545",
+			Packages: []string{"curl", "libcurl-rustls4"},
+		},
+		{
+			ID:       "CVE-2023-38546",
+			Packages: []string{"curl"},
+		},
+		{
+			ID:       "GHSA-4mpg-2xm8-qq8v",
+			Packages: []string{"libcurl-rustls4"},
+		},
+	}
+
+	got := ListVulnerabilities(advisoryDocs)
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("ListVulnerabilities() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -42,15 +42,19 @@ func cmdAdvisory() *cobra.Command {
 		cmdAdvisoryCreate(),
 		cmdAdvisoryDiff(),
 		cmdAdvisoryDiscover(),
+		cmdAdvisoryDraft(),
 		cmdAdvisoryExport(),
 		cmdAdvisoryGuide(),
+		cmdAdvisoryHotspots(),
 		cmdAdvisoryID(),
 		cmdAdvisoryList(),
 		cmdAdvisoryMigrateIDs(),
 		cmdAdvisoryOSV(),
 		cmdAdvisorySecDB(),
+		cmdAdvisoryUnreviewed(),
 		cmdAdvisoryUpdate(),
 		cmdAdvisoryValidate(),
+		cmdAdvisoryVulns(),
 	)
 
 	return cmd
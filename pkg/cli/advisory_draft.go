@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	rwos "github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os"
+	"github.com/wolfi-dev/wolfictl/pkg/distro"
+	"github.com/wolfi-dev/wolfictl/pkg/scan"
+	"gopkg.in/yaml.v3"
+)
+
+func cmdAdvisoryDraft() *cobra.Command {
+	p := &draftParams{}
+	cmd := &cobra.Command{
+		Use:   "draft",
+		Short: "Draft advisories for scan findings that have no advisory yet",
+		Long: `Draft advisories for scan findings that have no advisory yet.
+
+The 'draft' command reads the JSON results of a scan (from 'wolfictl scan -o
+json') and prints skeleton advisory documents as YAML for each finding that
+isn't already referenced by an advisory for the scanned package. Findings for
+the same vulnerability, across all scanned subpackages of an origin package,
+are drafted as one advisory. Each drafted advisory has a detection event,
+timestamped now, for each place the vulnerability was found.
+
+	wolfictl scan -o json ko-0.15.4-r1.apk > scan.json
+	wolfictl adv draft --scan scan.json -p ko
+
+Nothing is written to the advisories repository. Use the output as a starting
+point for triage.
+`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if p.scanResultsPath == "" {
+				return fmt.Errorf("need --scan")
+			}
+
+			advisoriesRepoDir := resolveAdvisoriesDirInput(p.advisoriesRepoDir)
+			if advisoriesRepoDir == "" {
+				if p.doNotDetectDistro {
+					return fmt.Errorf("no advisories repo dir specified")
+				}
+
+				d, err := distro.Detect()
+				if err != nil {
+					return fmt.Errorf("no advisories repo dir specified, and distro auto-detection failed: %w", err)
+				}
+
+				advisoriesRepoDir = d.Local.AdvisoriesRepo.Dir
+				_, _ = fmt.Fprint(os.Stderr, renderDetectedDistro(d))
+			}
+
+			advisoryDocs, err := v2.NewIndex(cmd.Context(), rwos.DirFS(advisoriesRepoDir))
+			if err != nil {
+				return fmt.Errorf("unable to index advisory configs for directory %q: %w", advisoriesRepoDir, err)
+			}
+
+			f, err := os.Open(p.scanResultsPath)
+			if err != nil {
+				return fmt.Errorf("opening scan results: %w", err)
+			}
+			defer f.Close()
+
+			var results []scan.Result
+			if err := json.NewDecoder(f).Decode(&results); err != nil {
+				return fmt.Errorf("decoding scan results from %q: %w", p.scanResultsPath, err)
+			}
+
+			if p.packageName != "" {
+				results = slices.DeleteFunc(results, func(r scan.Result) bool {
+					return r.TargetAPK.Origin() != p.packageName
+				})
+			}
+
+			// Drafts for subpackages belong in the origin package's document.
+			drafts, err := scan.DraftAdvisories(cmd.Context(), results, advisoryDocs, v2.Now())
+			if err != nil {
+				return fmt.Errorf("drafting advisories: %w", err)
+			}
+
+			enc := yaml.NewEncoder(os.Stdout)
+			enc.SetIndent(2)
+			defer enc.Close()
+
+			for _, origin := range slices.Sorted(maps.Keys(drafts)) {
+				doc := v2.Document{
+					SchemaVersion: v2.SchemaVersion,
+					Package:       v2.Package{Name: origin},
+					Advisories:    drafts[origin],
+				}
+
+				if err := enc.Encode(doc); err != nil {
+					return fmt.Errorf("encoding draft advisories for %s: %w", origin, err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	p.addFlagsTo(cmd)
+	return cmd
+}
+
+type draftParams struct {
+	doNotDetectDistro bool
+	advisoriesRepoDir string
+	packageName       string
+	scanResultsPath   string
+}
+
+func (p *draftParams) addFlagsTo(cmd *cobra.Command) {
+	addNoDistroDetectionFlag(&p.doNotDetectDistro, cmd)
+	addAdvisoriesDirFlag(&p.advisoriesRepoDir, cmd)
+	addPackageFlag(&p.packageName, cmd)
+
+	cmd.Flags().StringVar(&p.scanResultsPath, "scan", "", "path to scan results in JSON format (from 'wolfictl scan -o json')")
+}
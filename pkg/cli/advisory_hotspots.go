@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+	"github.com/wolfi-dev/wolfictl/pkg/advisory"
+)
+
+func cmdAdvisoryHotspots() *cobra.Command {
+	p := &hotspotsParams{}
+	cmd := &cobra.Command{
+		Use:   "hotspots",
+		Short: "List packages by how much their advisory data has changed in git history",
+		Long: `List packages by how much their advisory data has changed in git history.
+
+The 'hotspots' command walks a range of the advisories repository's git
+history and, for each package, counts the commits that changed its advisories
+and the advisories that were added, modified (e.g. by adding an event as the
+vulnerability's status evolved) and removed. Packages with any changes are
+listed from most to least changes. This highlights the packages that need the
+most security maintenance.
+
+The range works like 'git log FROM..TO'. For example, to see the changes made
+since the v1 tag:
+
+	wolfictl adv hotspots --from v1
+
+Without --from, all of the history of --to (default HEAD) is walked. Merge
+commits are skipped, since their changes are counted in the commits being
+merged.
+
+Each line shows the package name, the number of commits, and the number of
+added, modified and removed advisories.
+`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if p.advisoriesRepoDir == "" {
+				p.advisoriesRepoDir = "." // default to current working directory
+			}
+
+			repo, err := git.PlainOpenWithOptions(p.advisoriesRepoDir, &git.PlainOpenOptions{DetectDotGit: true})
+			if err != nil {
+				return fmt.Errorf("opening git repository for %q; cd to an advisories repository, or use -a flag: %w", p.advisoriesRepoDir, err)
+			}
+
+			opts := advisory.HotspotsOptions{
+				Repository: repo,
+			}
+
+			to, err := repo.ResolveRevision(plumbing.Revision(p.to))
+			if err != nil {
+				return fmt.Errorf("resolving revision %q: %w", p.to, err)
+			}
+			opts.To = *to
+
+			if p.from != "" {
+				from, err := repo.ResolveRevision(plumbing.Revision(p.from))
+				if err != nil {
+					return fmt.Errorf("resolving revision %q: %w", p.from, err)
+				}
+				opts.From = *from
+			}
+
+			activity, err := advisory.Hotspots(opts)
+			if err != nil {
+				return err
+			}
+
+			var width int
+			for _, a := range activity {
+				if l := len(a.Package); l > width {
+					width = l
+				}
+			}
+
+			for _, a := range activity {
+				fmt.Printf(
+					"%-*s  commits: %d  added: %d  modified: %d  removed: %d\n",
+					width,
+					a.Package,
+					a.Commits,
+					a.AdvisoriesAdded,
+					a.AdvisoriesModified,
+					a.AdvisoriesRemoved,
+				)
+			}
+
+			return nil
+		},
+	}
+
+	p.addFlagsTo(cmd)
+	return cmd
+}
+
+type hotspotsParams struct {
+	advisoriesRepoDir string
+	from, to          string
+}
+
+func (p *hotspotsParams) addFlagsTo(cmd *cobra.Command) {
+	addAdvisoriesDirFlag(&p.advisoriesRepoDir, cmd)
+
+	cmd.Flags().StringVar(&p.from, "from", "", "git revision where the range of history begins, exclusive")
+	cmd.Flags().StringVar(&p.to, "to", "HEAD", "git revision where the range of history ends, inclusive")
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wolfi-dev/wolfictl/pkg/advisory"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	buildconfigs "github.com/wolfi-dev/wolfictl/pkg/configs/build"
+	rwos "github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os"
+	"github.com/wolfi-dev/wolfictl/pkg/distro"
+)
+
+func cmdAdvisoryUnreviewed() *cobra.Command {
+	p := &unreviewedParams{}
+	cmd := &cobra.Command{
+		Use:   "unreviewed",
+		Short: "List distro packages that have no advisories",
+		Long: `List distro packages that have no advisories.
+
+The 'unreviewed' command prints the name of each package defined in the distro
+repository that has no advisory data, one per line. This includes packages with
+no advisory document at all, and packages whose advisory document has no
+advisories in it.
+
+A package in this list has never had any security data recorded for it. This
+is different from a package whose advisories are all resolved.
+`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			distroRepoDir := resolveDistroDir(p.distroRepoDir)
+			advisoriesRepoDir := resolveAdvisoriesDirInput(p.advisoriesRepoDir)
+			if distroRepoDir == "" || advisoriesRepoDir == "" {
+				if p.doNotDetectDistro {
+					return fmt.Errorf("distro repo dir and/or advisories repo dir was left unspecified")
+				}
+
+				d, err := distro.Detect()
+				if err != nil {
+					return fmt.Errorf("distro repo dir and/or advisories repo dir was left unspecified, and distro auto-detection failed: %w", err)
+				}
+
+				distroRepoDir = d.Local.PackagesRepo.Dir
+				advisoriesRepoDir = d.Local.AdvisoriesRepo.Dir
+				_, _ = fmt.Fprint(os.Stderr, renderDetectedDistro(d))
+			}
+
+			advisoryDocs, err := v2.NewIndex(cmd.Context(), rwos.DirFS(advisoriesRepoDir))
+			if err != nil {
+				return fmt.Errorf("unable to index advisory configs for directory %q: %w", advisoriesRepoDir, err)
+			}
+
+			buildCfgs, err := buildconfigs.NewIndex(cmd.Context(), rwos.DirFS(distroRepoDir))
+			if err != nil {
+				return fmt.Errorf("unable to index build configs for directory %q: %w", distroRepoDir, err)
+			}
+
+			for _, name := range advisory.PackagesWithoutAdvisories(buildCfgs, advisoryDocs) {
+				fmt.Println(name)
+			}
+
+			return nil
+		},
+	}
+
+	p.addFlagsTo(cmd)
+	return cmd
+}
+
+type unreviewedParams struct {
+	doNotDetectDistro bool
+
+	distroRepoDir, advisoriesRepoDir string
+}
+
+func (p *unreviewedParams) addFlagsTo(cmd *cobra.Command) {
+	addNoDistroDetectionFlag(&p.doNotDetectDistro, cmd)
+
+	addDistroDirFlag(&p.distroRepoDir, cmd)
+	addAdvisoriesDirFlag(&p.advisoriesRepoDir, cmd)
+}
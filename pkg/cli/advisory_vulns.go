@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wolfi-dev/wolfictl/pkg/advisory"
+	"github.com/wolfi-dev/wolfictl/pkg/configs"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	rwos "github.com/wolfi-dev/wolfictl/pkg/configs/rwfs/os"
+	"github.com/wolfi-dev/wolfictl/pkg/distro"
+)
+
+func cmdAdvisoryVulns() *cobra.Command {
+	p := &vulnsParams{}
+	cmd := &cobra.Command{
+		Use:   "vulns",
+		Short: "List all vulnerability IDs referenced by advisories",
+		Long: `List all vulnerability IDs referenced by advisories.
+
+The 'vulns' command prints every vulnerability ID (such as a CVE or GHSA ID)
+cited by the advisory data, either as an advisory's ID or as one of its aliases.
+Each vulnerability ID is printed once, in sorted order, followed by the names of
+the packages whose advisories cite it:
+
+	CVE-2023-38545	curl,libcurl-rustls4
+
+CGA IDs identify advisories rather than vulnerabilities, so they're not listed.
+`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if len(p.advisoriesRepoDirs) == 0 {
+				if p.doNotDetectDistro {
+					return fmt.Errorf("no advisories repo dir specified")
+				}
+
+				d, err := distro.Detect()
+				if err != nil {
+					return fmt.Errorf("no advisories repo dir specified, and distro auto-detection failed: %w", err)
+				}
+
+				p.advisoriesRepoDirs = append(p.advisoriesRepoDirs, d.Local.AdvisoriesRepo.Dir)
+				_, _ = fmt.Fprint(os.Stderr, renderDetectedDistro(d))
+			}
+
+			indices := make([]*configs.Index[v2.Document], 0, len(p.advisoriesRepoDirs))
+			for _, dir := range p.advisoriesRepoDirs {
+				advisoryFsys := rwos.DirFS(dir)
+				index, err := v2.NewIndex(cmd.Context(), advisoryFsys)
+				if err != nil {
+					return fmt.Errorf("unable to index advisory configs for directory %q: %w", dir, err)
+				}
+
+				indices = append(indices, index)
+			}
+
+			for _, ref := range advisory.ListVulnerabilities(indices...) {
+				fmt.Printf("%s\t%s\n", ref.ID, strings.Join(ref.Packages, ","))
+			}
+
+			return nil
+		},
+	}
+
+	p.addFlagsTo(cmd)
+	return cmd
+}
+
+type vulnsParams struct {
+	doNotDetectDistro  bool
+	advisoriesRepoDirs []string
+}
+
+func (p *vulnsParams) addFlagsTo(cmd *cobra.Command) {
+	addNoDistroDetectionFlag(&p.doNotDetectDistro, cmd)
+
+	cmd.Flags().StringSliceVarP(&p.advisoriesRepoDirs, "advisories-repo-dir", "a", nil, "directory containing an advisories repository")
+}
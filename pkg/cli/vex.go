@@ -1,46 +1,70 @@
 package cli
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"chainguard.dev/apko/pkg/apk/apk"
+	"chainguard.dev/melange/pkg/config"
+	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/openvex/go-vex/pkg/vex"
+	"github.com/package-url/packageurl-go"
 	"github.com/spf13/cobra"
+	"github.com/wolfi-dev/wolfictl/pkg/index"
+	"github.com/wolfi-dev/wolfictl/pkg/scan"
+	wolfivex "github.com/wolfi-dev/wolfictl/pkg/vex"
 )
 
 func cmdVEX() *cobra.Command {
 	cmd := &cobra.Command{
-		Deprecated: "This command does nothing, and will be removed in a future version.",
-		Use:        "vex",
-		Short:      "Tools to generate VEX statements for Wolfi packages and images",
-		Long: `wolfictl vex: Tools to generate VEX statements for Wolfi packages and images
+		Use:   "vex",
+		Short: "Tools to work with VEX documents for Wolfi packages and images",
+		Long: `wolfictl vex: Tools to work with VEX documents for Wolfi packages and images
 
 The vex family of subcommands interacts with Wolfi data and configuration
-files to generate Vulnerability Exploitability eXchange (VEX) documents to
-inform downstream consumer how vulnerabilities impact Wolfi packages and images
-that use them.
+files to inspect and check Vulnerability Exploitability eXchange (VEX)
+documents, which inform downstream consumers how vulnerabilities impact Wolfi
+packages and images that use them.
 
-wolfictl can generate VEX data by reading the melange configuration files
-of each package and additional information coming from external documents.
-There are currently two VEX subcommands:
+There are currently five VEX subcommands:
 
- wolfictl vex package: Generates VEX documents from a list of melange configs
+ wolfictl vex purls: Prints the package URLs a melange config produces
 
- wolfictl vex sbom: Generates a VEX document by reading an image SBOM
+ wolfictl vex check-products: Checks that a VEX document's products exist in an APKINDEX
 
-For more information please see the help sections if these subcommands. To know
-more about the VEX tooling powering wolfictl see: https://openvex.dev/
+ wolfictl vex coverage: Summarizes how many scan findings a VEX document covers
+
+ wolfictl vex completeness: Checks that a VEX document assesses every package in an SBOM
 
+ wolfictl vex stale: Lists under_investigation statements older than a maximum age
 
+The 'vex package' and 'vex sbom' subcommands are deprecated and do nothing.
+
+For more information please see the help sections of these subcommands. To know
+more about the VEX tooling powering wolfictl see: https://openvex.dev/
 `,
 		SilenceErrors: true,
 	}
 
 	addPackage(cmd)
 	addSBOM(cmd)
+	addPurls(cmd)
+	addCheckProducts(cmd)
+	addCoverage(cmd)
+	addCompleteness(cmd)
+	addStale(cmd)
 	return cmd
 }
 
 func addPackage(parent *cobra.Command) {
 	cmd := &cobra.Command{
+		Deprecated:    "This command does nothing, and will be removed in a future version.",
 		Use:           "package CONFIG [CONFIG]...",
 		Example:       "wolfictl vex package --author=joe@doe.com config1.yaml config2.yaml",
 		Short:         "Generate a VEX document from package configuration files",
@@ -56,9 +80,10 @@ func addPackage(parent *cobra.Command) {
 
 func addSBOM(parent *cobra.Command) {
 	cmd := &cobra.Command{
-		Use:     "sbom [flags] sbom.spdx.json",
-		Example: "wolfictl vex sbom --author=joe@doe.com sbom.spdx.json",
-		Short:   "Generate a VEX document from wolfi packages listed in an SBOM",
+		Deprecated: "This command does nothing, and will be removed in a future version.",
+		Use:        "sbom [flags] sbom.spdx.json",
+		Example:    "wolfictl vex sbom --author=joe@doe.com sbom.spdx.json",
+		Short:      "Generate a VEX document from wolfi packages listed in an SBOM",
 		Long: `wolfictl vex sbom: Generate a VEX document from wolfi packages listed in an SBOM
 
 The vex sbom subcommand generates VEX documents describing how vulnerabilities
@@ -87,8 +112,299 @@ or, if not specified, it will clone the repo for you.
 	parent.AddCommand(cmd)
 }
 
+func addCheckProducts(parent *cobra.Command) {
+	var repo, distro string
+	var archs []string
+	cmd := &cobra.Command{
+		Use:     "check-products [flags] vex.json",
+		Example: "wolfictl vex check-products --package-repo-url=https://packages.wolfi.dev/os vex.json",
+		Short:   "Check that the products in a VEX document exist in an APKINDEX",
+		Long: `wolfictl vex check-products: Check that the products in a VEX document exist in an APKINDEX
+
+The vex check-products subcommand reads a VEX document and checks that every
+product purl in its statements names a package and version that's present in
+the APKINDEX of the given package repository, under the purl namespace of the
+given distro. Products that don't resolve are listed, and the command exits 1
+if there are any.
+
+A product is considered present if it's found in the APKINDEX of any of the
+given architectures. The package repository can also be the path to a local
+APKINDEX.tar.gz file, in which case the architectures are ignored.
+`,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if repo == "" {
+				return fmt.Errorf("need --%s", flagNamePackageRepoURL)
+			}
+			if len(archs) == 0 || slices.Contains(archs, "") {
+				return fmt.Errorf("need at least one --arch, with no empty values")
+			}
+
+			doc, err := vex.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("opening VEX document %q: %w", args[0], err)
+			}
+
+			indexes := make([]*apk.APKIndex, 0, len(archs))
+			for _, arch := range archs {
+				apkindex, err := index.Index(arch, repo)
+				if err != nil {
+					return fmt.Errorf("unable to load APKINDEX for %s: %w", arch, err)
+				}
+				indexes = append(indexes, apkindex)
+			}
+
+			unresolved := wolfivex.UnresolvedProducts(doc, distro, indexes...)
+			for _, purl := range unresolved {
+				fmt.Println(purl)
+			}
+
+			if len(unresolved) > 0 {
+				return fmt.Errorf("%d product(s) not found in APKINDEX", len(unresolved))
+			}
+
+			return nil
+		},
+	}
+	addPackageRepoURLFlag(&repo, cmd)
+	cmd.Flags().StringSliceVar(&archs, "arch", []string{"x86_64", "aarch64"}, "package architectures to load the APKINDEX for")
+	cmd.Flags().StringVar(&distro, "distro", "wolfi", "distro used as the purl namespace of the repository's packages")
+	parent.AddCommand(cmd)
+}
+
+func addCoverage(parent *cobra.Command) {
+	var vexPath, scanResultsPath, distro string
+	cmd := &cobra.Command{
+		Use:     "coverage --vex vex.json --scan scan.json",
+		Example: "wolfictl vex coverage --vex vex.json --scan scan.json",
+		Short:   "Summarize how many scan findings are covered by a VEX document",
+		Long: `wolfictl vex coverage: Summarize how many scan findings are covered by a VEX document
+
+The vex coverage subcommand reads a VEX document and the JSON results of a scan
+(from 'wolfictl scan -o json'), and reports how many of the vulnerabilities
+found in each scanned package are covered by a statement. A vulnerability is
+covered when the latest statement for the scanned package and the vulnerability
+(or any of its aliases) has a status of not_affected or fixed. A vulnerability
+found in several components of the same package is counted once, even when the
+components report it under different IDs that are aliases of each other.
+
+The counts of covered and uncovered vulnerabilities are printed, followed by the
+uncovered vulnerabilities, one per line.
+`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if vexPath == "" || scanResultsPath == "" {
+				return fmt.Errorf("need --vex and --scan")
+			}
+
+			doc, err := vex.Open(vexPath)
+			if err != nil {
+				return fmt.Errorf("opening VEX document %q: %w", vexPath, err)
+			}
+
+			f, err := os.Open(scanResultsPath)
+			if err != nil {
+				return fmt.Errorf("opening scan results: %w", err)
+			}
+			defer f.Close()
+
+			var results []scan.Result
+			if err := json.NewDecoder(f).Decode(&results); err != nil {
+				return fmt.Errorf("decoding scan results from %q: %w", scanResultsPath, err)
+			}
+
+			coverage := wolfivex.CoverageOf(doc, results, distro)
+
+			fmt.Printf("covered: %d\n", coverage.Covered)
+			fmt.Printf("uncovered: %d\n", len(coverage.Uncovered))
+			for _, u := range coverage.Uncovered {
+				fmt.Printf("%s\t%s\n", u.PURL, u.Vulnerability.ID)
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&vexPath, "vex", "", "path to the VEX document")
+	cmd.Flags().StringVar(&scanResultsPath, "scan", "", "path to scan results in JSON format (from 'wolfictl scan -o json')")
+	cmd.Flags().StringVar(&distro, "distro", "wolfi", "distro used as the purl namespace of scanned packages")
+	parent.AddCommand(cmd)
+}
+
+func addCompleteness(parent *cobra.Command) {
+	var vexPath, sbomPath string
+	cmd := &cobra.Command{
+		Use:     "completeness --vex vex.json --sbom sbom.json",
+		Example: "wolfictl vex completeness --vex vex.json --sbom image.spdx.json",
+		Short:   "Check that a VEX document assesses every distro package in an SBOM",
+		Long: `wolfictl vex completeness: Check that a VEX document assesses every distro package in an SBOM
+
+The vex completeness subcommand reads a VEX document and an image SBOM, and
+checks that every APK package in the SBOM is cited as a product by at least one
+statement. Packages without a statement are listed, and the command exits 1 if
+there are any.
+
+A product matches a package when their purl type, namespace, name and version
+are the same. A product without a version matches every version of the package.
+The SBOM can be in any format Syft can decode, such as SPDX, CycloneDX or Syft
+JSON.
+`,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if vexPath == "" || sbomPath == "" {
+				return fmt.Errorf("need --vex and --sbom")
+			}
+
+			doc, err := vex.Open(vexPath)
+			if err != nil {
+				return fmt.Errorf("opening VEX document %q: %w", vexPath, err)
+			}
+
+			f, err := os.Open(sbomPath)
+			if err != nil {
+				return fmt.Errorf("opening SBOM: %w", err)
+			}
+			defer f.Close()
+
+			s, _, _, err := format.Decode(f)
+			if err != nil {
+				return fmt.Errorf("decoding SBOM from %q: %w", sbomPath, err)
+			}
+
+			var purls []string
+			for _, p := range s.Artifacts.Packages.Sorted() {
+				if p.Type == pkg.ApkPkg && p.PURL != "" {
+					purls = append(purls, p.PURL)
+				}
+			}
+
+			unassessed := wolfivex.UnassessedPackages(doc, purls)
+			for _, purl := range unassessed {
+				fmt.Println(purl)
+			}
+
+			if len(unassessed) > 0 {
+				return fmt.Errorf("%d package(s) have no VEX statement", len(unassessed))
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&vexPath, "vex", "", "path to the VEX document")
+	cmd.Flags().StringVar(&sbomPath, "sbom", "", "path to the image SBOM")
+	parent.AddCommand(cmd)
+}
+
+func addStale(parent *cobra.Command) {
+	var maxAge time.Duration
+	cmd := &cobra.Command{
+		Use:     "stale [flags] vex.json",
+		Example: "wolfictl vex stale --max-age=720h vex.json",
+		Short:   "List under_investigation statements older than a maximum age",
+		Long: `wolfictl vex stale: List under_investigation statements older than a maximum age
+
+The vex stale subcommand reads a VEX document and lists each statement with a
+status of under_investigation whose timestamp is older than the maximum age.
+Statements without their own timestamp are aged using the document's
+timestamp. Each stale statement is printed on its own line, with its
+vulnerability, products and timestamp:
+
+	CVE-2023-38545	pkg:apk/wolfi/curl@8.4.0-r0	2023-10-11T00:00:00Z
+
+The command exits 1 if there are any stale statements.
+`,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			doc, err := vex.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("opening VEX document %q: %w", args[0], err)
+			}
+
+			stale := wolfivex.StaleInvestigations(doc, time.Now(), maxAge)
+			for i := range stale {
+				s := stale[i]
+
+				var products []string
+				for _, p := range s.Products {
+					products = append(products, p.ID)
+				}
+
+				ts := wolfivex.StatementTime(doc, s).Format(time.RFC3339)
+				fmt.Printf("%s\t%s\t%s\n", s.Vulnerability.Name, strings.Join(products, ","), ts)
+			}
+
+			if len(stale) > 0 {
+				return fmt.Errorf("%d under_investigation statement(s) older than %s", len(stale), maxAge)
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&maxAge, "max-age", 30*24*time.Hour, "maximum age of an under_investigation statement")
+	parent.AddCommand(cmd)
+}
+
 func addCommonVexFlags(cmd *cobra.Command) {
 	var s string
 	cmd.Flags().StringVar(&s, "author", "", "author of the VEX document")
 	cmd.Flags().StringVar(&s, "role", "", "role of the author of the VEX document")
 }
+
+func addPurls(parent *cobra.Command) {
+	var distro, arch string
+	cmd := &cobra.Command{
+		Use:     "purls CONFIG",
+		Example: "wolfictl vex purls --distro=wolfi --arch=x86_64 curl.yaml",
+		Short:   "Print the package URLs (purls) produced by a package configuration file",
+		Long: `wolfictl vex purls: Print the package URLs (purls) produced by a package configuration file
+
+The vex purls subcommand prints the package URLs that identify the origin
+package and each subpackage defined in a melange configuration file, one per
+line. These are the product identifiers used for the packages in VEX
+documents, so this is useful to verify the product mapping of a config.
+
+If an architecture is given, it's added to each purl as the "arch" qualifier.
+`,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.ParseConfiguration(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("parsing package configuration %q: %w", args[0], err)
+			}
+
+			for _, purl := range cfg.PackageURLs(distro) {
+				if arch != "" {
+					purl, err = addArchQualifier(purl, arch)
+					if err != nil {
+						return err
+					}
+				}
+
+				fmt.Println(purl)
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&distro, "distro", "wolfi", "distro used as the purl namespace")
+	cmd.Flags().StringVar(&arch, "arch", "", "package architecture to add as a purl qualifier")
+	parent.AddCommand(cmd)
+}
+
+// addArchQualifier sets the "arch" qualifier on the given purl.
+func addArchQualifier(purl, arch string) (string, error) {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return "", fmt.Errorf("parsing purl %q: %w", purl, err)
+	}
+
+	qualifiers := p.Qualifiers.Map()
+	qualifiers["arch"] = arch
+	p.Qualifiers = packageurl.QualifiersFromMap(qualifiers)
+
+	return p.ToString(), nil
+}
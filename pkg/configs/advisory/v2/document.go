@@ -62,7 +62,10 @@ func (doc Document) ValidateSchemaVersion() error {
 	return nil
 }
 
-func decodeDocument(r io.Reader) (*Document, error) {
+// DecodeDocument decodes an advisory document from YAML, rejecting unknown
+// fields. A document without a schema version is treated as schema version
+// "1". The document isn't otherwise validated.
+func DecodeDocument(r io.Reader) (*Document, error) {
 	doc := &Document{}
 	decoder := yaml.NewDecoder(r)
 	decoder.KnownFields(true)
@@ -264,7 +264,7 @@ func TestDocument_full_coverage(t *testing.T) {
 	t.Run("decode", func(t *testing.T) {
 		expected := testDocument
 
-		actual, err := decodeDocument(f)
+		actual, err := DecodeDocument(f)
 		require.NoError(t, err)
 
 		if diff := cmp.Diff(expected, *actual); diff != "" {
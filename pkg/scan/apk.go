@@ -58,6 +58,11 @@ func (t TargetAPK) Origin() string {
 	return t.Name
 }
 
+// PURL returns the package URL ("purl") of the APK within the given distro.
+func (t TargetAPK) PURL(distro string) string {
+	return fmt.Sprintf("pkg:apk/%s/%s@%s", distro, t.Name, t.Version)
+}
+
 func newTargetAPK(s *sbomSyft.SBOM) (TargetAPK, error) {
 	// There should be exactly one APK package in the SBOM, and it should be the APK
 	// we intended to scan.
@@ -0,0 +1,82 @@
+package scan
+
+import (
+	"cmp"
+	"slices"
+)
+
+// VulnerabilitiesByPURL correlates the findings in the given scan results to
+// the distro packages that were scanned. It returns a map of each scanned APK's
+// purl within the given distro to the vulnerabilities found in that APK, sorted
+// by ID. A vulnerability found more than once in the same APK (e.g. in multiple
+// components) is listed once, with the IDs and aliases from all of its findings
+// merged. Findings are for the same vulnerability if they share an ID or alias,
+// and the ID of the first of them is kept as the vulnerability's ID. APKs
+// without findings aren't included.
+func VulnerabilitiesByPURL(results []Result, distro string) map[string][]Vulnerability {
+	vulnsByPURL := make(map[string][]Vulnerability)
+
+	for i := range results {
+		result := results[i]
+		purl := result.TargetAPK.PURL(distro)
+
+		for _, f := range result.Findings {
+			vulnsByPURL[purl] = mergeIntoVulnerabilities(vulnsByPURL[purl], f.Vulnerability)
+		}
+	}
+
+	for purl, vulns := range vulnsByPURL {
+		for i := range vulns {
+			v := &vulns[i]
+			slices.Sort(v.Aliases)
+			v.Aliases = slices.DeleteFunc(slices.Compact(v.Aliases), func(alias string) bool {
+				return alias == v.ID
+			})
+			if len(v.Aliases) == 0 {
+				v.Aliases = nil
+			}
+		}
+
+		slices.SortFunc(vulns, func(a, b Vulnerability) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+		vulnsByPURL[purl] = vulns
+	}
+
+	return vulnsByPURL
+}
+
+// mergeIntoVulnerabilities adds a finding's vulnerability to the list. Every
+// vulnerability in the list that shares an ID or alias with it is merged with
+// it into a single vulnerability, as mergeIntoDrafts does for draft advisories.
+func mergeIntoVulnerabilities(vulns []Vulnerability, found Vulnerability) []Vulnerability {
+	ids := append([]string{found.ID}, found.Aliases...)
+
+	var rest []Vulnerability
+	merged := -1
+
+	for _, v := range vulns {
+		if !slices.Contains(ids, v.ID) && !slices.ContainsFunc(v.Aliases, func(alias string) bool { return slices.Contains(ids, alias) }) {
+			rest = append(rest, v)
+			continue
+		}
+
+		if merged == -1 {
+			// Keep the position and ID of the first matching vulnerability.
+			merged = len(rest)
+			rest = append(rest, v)
+			continue
+		}
+
+		rest[merged].Aliases = append(rest[merged].Aliases, v.ID)
+		rest[merged].Aliases = append(rest[merged].Aliases, v.Aliases...)
+	}
+
+	if merged == -1 {
+		found.Aliases = slices.Clone(found.Aliases)
+		return append(rest, found)
+	}
+
+	rest[merged].Aliases = append(rest[merged].Aliases, ids...)
+	return rest
+}
@@ -0,0 +1,83 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVulnerabilitiesByPURL(t *testing.T) {
+	results := []Result{
+		{
+			TargetAPK: TargetAPK{Name: "crane", Version: "0.19.1-r0"},
+			Findings: []Finding{
+				{
+					Package:       Package{Name: "golang.org/x/net", Type: "go-module"},
+					Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288"}},
+				},
+				{
+					Package:       Package{Name: "stdlib", Type: "go-module"},
+					Vulnerability: Vulnerability{ID: "CVE-2024-24789"},
+				},
+				{
+					Package:       Package{Name: "golang.org/x/net", Type: "go-module", Location: "/usr/bin/gcrane"},
+					Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"GO-2024-2687"}},
+				},
+				{
+					// The same vulnerability, reported under one of its aliases.
+					Package:       Package{Name: "stdlib", Type: "go-module"},
+					Vulnerability: Vulnerability{ID: "CVE-2023-45288"},
+				},
+			},
+		},
+		{
+			TargetAPK: TargetAPK{Name: "py3-setuptools", Version: "69.5.1-r0", OriginPackageName: "py3-setuptools"},
+			Findings: []Finding{
+				{
+					Package:       Package{Name: "setuptools", Type: "python"},
+					Vulnerability: Vulnerability{ID: "GHSA-cx63-2mw6-8hw5"},
+				},
+			},
+		},
+		{
+			TargetAPK: TargetAPK{Name: "ko", Version: "0.15.4-r0"},
+			Findings: []Finding{
+				{
+					Package:       Package{Name: "stdlib", Type: "go-module"},
+					Vulnerability: Vulnerability{ID: "CVE-2024-24790"},
+				},
+				{
+					Package:       Package{Name: "stdlib", Type: "go-module", Location: "/usr/bin/crane"},
+					Vulnerability: Vulnerability{ID: "GO-2024-2887"},
+				},
+				{
+					// Links the two findings above as the same vulnerability.
+					Package:       Package{Name: "stdlib", Type: "go-module", Location: "/usr/bin/gcrane"},
+					Vulnerability: Vulnerability{ID: "GO-2024-2887", Aliases: []string{"CVE-2024-24790"}},
+				},
+			},
+		},
+		{
+			TargetAPK: TargetAPK{Name: "busybox", Version: "1.36.1-r7"},
+		},
+	}
+
+	expected := map[string][]Vulnerability{
+		"pkg:apk/wolfi/crane@0.19.1-r0": {
+			{ID: "CVE-2024-24789"},
+			{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288", "GO-2024-2687"}},
+		},
+		"pkg:apk/wolfi/ko@0.15.4-r0": {
+			{ID: "CVE-2024-24790", Aliases: []string{"GO-2024-2887"}},
+		},
+		"pkg:apk/wolfi/py3-setuptools@69.5.1-r0": {
+			{ID: "GHSA-cx63-2mw6-8hw5"},
+		},
+	}
+
+	got := VulnerabilitiesByPURL(results, "wolfi")
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("VulnerabilitiesByPURL() mismatch (-want +got):\n%s", diff)
+	}
+}
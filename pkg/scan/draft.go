@@ -0,0 +1,126 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/wolfi-dev/wolfictl/pkg/advisory"
+	"github.com/wolfi-dev/wolfictl/pkg/configs"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+)
+
+// DraftAdvisories returns draft advisories for the findings in the given
+// results that aren't already referenced by any advisory for the scanned
+// packages. The drafts are grouped by the name of the origin package, since
+// that's the advisory document they belong in.
+//
+// Findings that share a vulnerability ID or alias, whether in the same result
+// or in results for different subpackages of the same origin, are merged into a
+// single draft advisory. Its aliases are the union of the findings' IDs and
+// aliases, and it has one scan/v1 detection event, with the given timestamp, for
+// each distinct place the vulnerability was found.
+func DraftAdvisories(ctx context.Context, results []Result, advisoryDocIndex *configs.Index[v2.Document], ts v2.Timestamp) (map[string][]v2.Advisory, error) {
+	draftsByOrigin := make(map[string][]*draft)
+
+	for i := range results {
+		result := results[i]
+
+		findings, err := FilterWithAdvisories(ctx, result, advisoryDocIndex, AdvisoriesSetAll)
+		if err != nil {
+			return nil, err
+		}
+
+		origin := result.TargetAPK.Origin()
+		for _, f := range findings {
+			ids := append([]string{f.Vulnerability.ID}, f.Vulnerability.Aliases...)
+			event := detectionEvent(result.TargetAPK, f, ts)
+			draftsByOrigin[origin] = mergeIntoDrafts(draftsByOrigin[origin], ids, event)
+		}
+	}
+
+	advisoriesByOrigin := make(map[string][]v2.Advisory, len(draftsByOrigin))
+	for origin, drafts := range draftsByOrigin {
+		for _, d := range drafts {
+			id, err := advisory.GenerateCGAID()
+			if err != nil {
+				return nil, fmt.Errorf("generating advisory ID for %s: %w", d.ids[0], err)
+			}
+
+			aliases := slices.Clone(d.ids)
+			slices.Sort(aliases)
+
+			advisoriesByOrigin[origin] = append(advisoriesByOrigin[origin], v2.Advisory{
+				ID:      id,
+				Aliases: slices.Compact(aliases),
+				Events:  d.events,
+			})
+		}
+	}
+
+	return advisoriesByOrigin, nil
+}
+
+// draft is a draft advisory that's still being assembled from findings.
+type draft struct {
+	ids    []string
+	events []v2.Event
+}
+
+// mergeIntoDrafts adds a finding, identified by its vulnerability ID and
+// aliases, to the drafts. Every draft that shares one of the IDs is merged with
+// the finding into a single draft, so that no two drafts share an ID.
+func mergeIntoDrafts(drafts []*draft, ids []string, event v2.Event) []*draft {
+	merged := &draft{}
+	var rest []*draft
+
+	for _, d := range drafts {
+		if slices.ContainsFunc(d.ids, func(id string) bool { return slices.Contains(ids, id) }) {
+			if len(merged.ids) == 0 {
+				// Keep the position of the first matching draft.
+				rest = append(rest, merged)
+			}
+
+			merged.ids = append(merged.ids, d.ids...)
+			merged.events = append(merged.events, d.events...)
+			continue
+		}
+
+		rest = append(rest, d)
+	}
+
+	if len(merged.ids) == 0 {
+		rest = append(rest, merged)
+	}
+
+	for _, id := range ids {
+		if !slices.Contains(merged.ids, id) {
+			merged.ids = append(merged.ids, id)
+		}
+	}
+
+	if !slices.Contains(merged.events, event) {
+		merged.events = append(merged.events, event)
+	}
+
+	return rest
+}
+
+func detectionEvent(apk TargetAPK, f Finding, ts v2.Timestamp) v2.Event {
+	return v2.Event{
+		Timestamp: ts,
+		Type:      v2.EventTypeDetection,
+		Data: v2.Detection{
+			Type: v2.DetectionTypeScanV1,
+			Data: v2.DetectionScanV1{
+				SubpackageName:    apk.Name,
+				ComponentID:       f.Package.ID,
+				ComponentName:     f.Package.Name,
+				ComponentVersion:  f.Package.Version,
+				ComponentType:     f.Package.Type,
+				ComponentLocation: f.Package.Location,
+				Scanner:           v2.DetectionScannerGrype,
+			},
+		},
+	}
+}
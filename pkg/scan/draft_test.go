@@ -0,0 +1,207 @@
+package scan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+	v2 "github.com/wolfi-dev/wolfictl/pkg/configs/advisory/v2"
+	"github.com/wolfi-dev/wolfictl/pkg/vuln"
+)
+
+func TestDraftAdvisories(t *testing.T) {
+	ts := v2.Timestamp(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	xnet := Package{
+		ID:       "0123456789abcdef",
+		Name:     "golang.org/x/net",
+		Version:  "0.22.0",
+		Type:     "go-module",
+		Location: "/usr/bin/ko",
+	}
+	stdlib := Package{
+		ID:       "fedcba9876543210",
+		Name:     "stdlib",
+		Version:  "go1.22.1",
+		Type:     "go-module",
+		Location: "/usr/bin/ko",
+	}
+
+	detection := func(subpackage string, p Package) v2.Event {
+		return v2.Event{
+			Timestamp: ts,
+			Type:      v2.EventTypeDetection,
+			Data: v2.Detection{
+				Type: v2.DetectionTypeScanV1,
+				Data: v2.DetectionScanV1{
+					SubpackageName:    subpackage,
+					ComponentID:       p.ID,
+					ComponentName:     p.Name,
+					ComponentVersion:  p.Version,
+					ComponentType:     p.Type,
+					ComponentLocation: p.Location,
+					Scanner:           v2.DetectionScannerGrype,
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		results  []Result
+		expected map[string][]v2.Advisory
+	}{
+		{
+			name: "same vulnerability in multiple components",
+			results: []Result{
+				{
+					TargetAPK: TargetAPK{Name: "ko-fips", Version: "42", OriginPackageName: "ko"},
+					Findings: []Finding{
+						{
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288"}},
+						},
+						{
+							// Already covered by an existing advisory for ko.
+							Package:       stdlib,
+							Vulnerability: Vulnerability{ID: "CVE-1999-11111"},
+						},
+						{
+							Package:       stdlib,
+							Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m"},
+						},
+						{
+							// Exact duplicate of the first finding.
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288"}},
+						},
+					},
+				},
+			},
+			expected: map[string][]v2.Advisory{
+				"ko": {
+					{
+						Aliases: []string{"CVE-2023-45288", "GHSA-4v7x-pqxf-cx7m"},
+						Events: []v2.Event{
+							detection("ko-fips", xnet),
+							detection("ko-fips", stdlib),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "findings whose IDs alias each other",
+			results: []Result{
+				{
+					TargetAPK: TargetAPK{Name: "ko", Version: "42"},
+					Findings: []Finding{
+						{
+							Package:       stdlib,
+							Vulnerability: Vulnerability{ID: "CVE-2024-24790"},
+						},
+						{
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "CVE-2023-45288"},
+						},
+						{
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288", "GO-2024-2687"}},
+						},
+						{
+							Package:       stdlib,
+							Vulnerability: Vulnerability{ID: "GO-2024-2687"},
+						},
+					},
+				},
+			},
+			expected: map[string][]v2.Advisory{
+				"ko": {
+					{
+						Aliases: []string{"CVE-2024-24790"},
+						Events:  []v2.Event{detection("ko", stdlib)},
+					},
+					{
+						Aliases: []string{"CVE-2023-45288", "GHSA-4v7x-pqxf-cx7m", "GO-2024-2687"},
+						Events: []v2.Event{
+							detection("ko", xnet),
+							detection("ko", stdlib),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subpackages of the same origin",
+			results: []Result{
+				{
+					TargetAPK: TargetAPK{Name: "ko", Version: "42"},
+					Findings: []Finding{
+						{
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288"}},
+						},
+					},
+				},
+				{
+					TargetAPK: TargetAPK{Name: "ko-fips", Version: "42", OriginPackageName: "ko"},
+					Findings: []Finding{
+						{
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "CVE-2023-45288"},
+						},
+					},
+				},
+				{
+					TargetAPK: TargetAPK{Name: "crane", Version: "0.19.1-r0"},
+					Findings: []Finding{
+						{
+							Package:       xnet,
+							Vulnerability: Vulnerability{ID: "CVE-2023-45288"},
+						},
+					},
+				},
+			},
+			expected: map[string][]v2.Advisory{
+				"ko": {
+					{
+						Aliases: []string{"CVE-2023-45288", "GHSA-4v7x-pqxf-cx7m"},
+						Events: []v2.Event{
+							detection("ko", xnet),
+							detection("ko-fips", xnet),
+						},
+					},
+				},
+				"crane": {
+					{
+						Aliases: []string{"CVE-2023-45288"},
+						Events:  []v2.Event{detection("crane", xnet)},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			drafts, err := DraftAdvisories(context.Background(), tt.results, getSingleAdvisoriesIndex(t), ts)
+			require.NoError(t, err)
+
+			for _, advs := range drafts {
+				for _, adv := range advs {
+					require.Regexp(t, vuln.RegexCGA, adv.ID)
+				}
+
+				// The drafts must be valid as a document's advisories.
+				require.NoError(t, v2.Advisories(advs).Validate())
+			}
+
+			if diff := cmp.Diff(tt.expected, drafts, cmpopts.IgnoreFields(v2.Advisory{}, "ID")); diff != "" {
+				t.Errorf("DraftAdvisories() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
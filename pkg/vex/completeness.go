@@ -0,0 +1,48 @@
+package vex
+
+import (
+	"slices"
+
+	"github.com/openvex/go-vex/pkg/vex"
+	"github.com/package-url/packageurl-go"
+)
+
+// UnassessedPackages returns the given package purls that aren't cited as a
+// product by any of the document's statements. A product matches a package
+// when their purl type, namespace, name and version are the same; qualifiers
+// are ignored. A product purl without a version matches every version of the
+// package. The list is sorted and has no duplicates.
+func UnassessedPackages(doc *vex.VEX, purls []string) []string {
+	var products []packageurl.PackageURL
+	for _, purl := range ProductPURLs(doc) {
+		if p, err := packageurl.FromString(purl); err == nil {
+			products = append(products, p)
+		}
+	}
+
+	var unassessed []string
+	for _, purl := range purls {
+		p, err := packageurl.FromString(purl)
+		if err != nil {
+			unassessed = append(unassessed, purl)
+			continue
+		}
+
+		if !slices.ContainsFunc(products, func(product packageurl.PackageURL) bool {
+			return productMatchesPackage(product, p)
+		}) {
+			unassessed = append(unassessed, purl)
+		}
+	}
+
+	slices.Sort(unassessed)
+	return slices.Compact(unassessed)
+}
+
+func productMatchesPackage(product, p packageurl.PackageURL) bool {
+	if product.Type != p.Type || product.Namespace != p.Namespace || product.Name != p.Name {
+		return false
+	}
+
+	return product.Version == "" || product.Version == p.Version
+}
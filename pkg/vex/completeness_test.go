@@ -0,0 +1,45 @@
+package vex
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openvex/go-vex/pkg/vex"
+)
+
+func TestUnassessedPackages(t *testing.T) {
+	doc := &vex.VEX{
+		Statements: []vex.Statement{
+			{
+				Products: []vex.Product{
+					{Component: vex.Component{ID: "pkg:apk/wolfi/curl@8.4.0-r0?arch=x86_64"}},
+				},
+			},
+			{
+				Products: []vex.Product{
+					{Component: vex.Component{ID: "pkg:apk/wolfi/zlib"}},
+				},
+			},
+		},
+	}
+
+	purls := []string{
+		"pkg:apk/wolfi/curl@8.4.0-r0?arch=x86_64&distro=wolfi-20230201",
+		"pkg:apk/wolfi/curl@8.3.0-r0?arch=x86_64&distro=wolfi-20230201",
+		"pkg:apk/wolfi/libcurl4@8.4.0-r0?arch=x86_64&distro=wolfi-20230201",
+		"pkg:apk/wolfi/zlib@1.3-r0?arch=x86_64&distro=wolfi-20230201",
+		"pkg:apk/alpine/zlib@1.3-r0?arch=x86_64&distro=alpine-3.18.4",
+	}
+
+	expected := []string{
+		"pkg:apk/alpine/zlib@1.3-r0?arch=x86_64&distro=alpine-3.18.4",
+		"pkg:apk/wolfi/curl@8.3.0-r0?arch=x86_64&distro=wolfi-20230201",
+		"pkg:apk/wolfi/libcurl4@8.4.0-r0?arch=x86_64&distro=wolfi-20230201",
+	}
+
+	got := UnassessedPackages(doc, purls)
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("UnassessedPackages() mismatch (-want +got):\n%s", diff)
+	}
+}
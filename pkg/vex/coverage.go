@@ -0,0 +1,147 @@
+package vex
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/openvex/go-vex/pkg/vex"
+	"github.com/package-url/packageurl-go"
+	"github.com/wolfi-dev/wolfictl/pkg/scan"
+)
+
+// Coverage describes how many of the vulnerabilities found by a scan are
+// addressed by the statements in a VEX document.
+type Coverage struct {
+	// Covered is the number of vulnerabilities, per scanned package, whose latest
+	// matching statement has a status of not_affected or fixed.
+	Covered int
+
+	// Uncovered lists the vulnerabilities that have no matching statement, or
+	// whose latest matching statement has any other status.
+	Uncovered []UncoveredVulnerability
+}
+
+// UncoveredVulnerability is a vulnerability found in a scanned package that's
+// not covered by a VEX document.
+type UncoveredVulnerability struct {
+	// PURL is the purl of the scanned distro package the vulnerability was found
+	// in.
+	PURL string
+
+	Vulnerability scan.Vulnerability
+}
+
+// CoverageOf reports how many of the vulnerabilities found in the given scan
+// results are covered by the document's statements. Findings are correlated to
+// the scanned packages' purls within the given distro using
+// scan.VulnerabilitiesByPURL. A statement matches a vulnerability if one of its
+// products matches the purl of the scanned package, ignoring qualifiers (as in
+// UnassessedPackages), and its vulnerability matches the vulnerability's ID or
+// one of its aliases. Uncovered vulnerabilities are sorted by purl, then by ID.
+func CoverageOf(doc *vex.VEX, results []scan.Result, distro string) Coverage {
+	var c Coverage
+
+	vulnsByPURL := scan.VulnerabilitiesByPURL(results, distro)
+	purls := slices.Sorted(maps.Keys(vulnsByPURL))
+
+	for _, purl := range purls {
+		for _, v := range vulnsByPURL[purl] {
+			if isCovered(doc, purl, v) {
+				c.Covered++
+				continue
+			}
+
+			c.Uncovered = append(c.Uncovered, UncoveredVulnerability{
+				PURL:          purl,
+				Vulnerability: v,
+			})
+		}
+	}
+
+	return c
+}
+
+func isCovered(doc *vex.VEX, purl string, v scan.Vulnerability) bool {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return false
+	}
+
+	// The statements for all of the vulnerability's IDs are considered together,
+	// so that an older statement under one alias can't outweigh a newer statement
+	// under another.
+	ids := append([]string{v.ID}, v.Aliases...)
+
+	latest := latestStatement(doc, matchingStatements(doc, p, ids))
+	if latest == nil {
+		return false
+	}
+
+	switch latest.Status {
+	case vex.StatusNotAffected, vex.StatusFixed:
+		return true
+	}
+
+	return false
+}
+
+// matchingStatements returns the document's statements for any of the given
+// vulnerability IDs that have a product matching the package. Products are
+// matched using productMatchesPackage, so qualifiers such as arch are ignored.
+func matchingStatements(doc *vex.VEX, p packageurl.PackageURL, ids []string) []vex.Statement {
+	var matches []vex.Statement
+
+	for i := range doc.Statements {
+		s := doc.Statements[i]
+		if !slices.ContainsFunc(ids, func(id string) bool { return statementIsForVulnerability(s, id) }) {
+			continue
+		}
+
+		if slices.ContainsFunc(s.Products, func(product vex.Product) bool {
+			pp, err := packageurl.FromString(productPURL(product.Component))
+			return err == nil && productMatchesPackage(pp, p)
+		}) {
+			matches = append(matches, s)
+		}
+	}
+
+	return matches
+}
+
+func statementIsForVulnerability(s vex.Statement, id string) bool {
+	v := s.Vulnerability
+	return string(v.Name) == id || v.ID == id || slices.Contains(v.Aliases, vex.VulnerabilityID(id))
+}
+
+// latestStatement returns the most recent of the given statements, using the
+// document's timestamp for statements that don't have their own.
+func latestStatement(doc *vex.VEX, statements []vex.Statement) *vex.Statement {
+	var latest *vex.Statement
+	var latestTime time.Time
+
+	for i := range statements {
+		t := StatementTime(doc, statements[i])
+		if latest == nil || !t.Before(latestTime) {
+			latest = &statements[i]
+			latestTime = t
+		}
+	}
+
+	return latest
+}
+
+// StatementTime returns the time the statement was made: its own timestamp, or
+// the document's timestamp if the statement doesn't have one. It returns the
+// zero time if neither is set.
+func StatementTime(doc *vex.VEX, s vex.Statement) time.Time {
+	if s.Timestamp != nil && !s.Timestamp.IsZero() {
+		return *s.Timestamp
+	}
+
+	if doc.Timestamp != nil {
+		return *doc.Timestamp
+	}
+
+	return time.Time{}
+}
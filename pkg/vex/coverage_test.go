@@ -0,0 +1,137 @@
+package vex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openvex/go-vex/pkg/vex"
+	"github.com/wolfi-dev/wolfictl/pkg/scan"
+)
+
+func TestCoverageOf(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ko := []vex.Product{{Component: vex.Component{ID: "pkg:apk/wolfi/ko@0.15.4-r1"}}}
+	koX8664 := []vex.Product{{Component: vex.Component{ID: "pkg:apk/wolfi/ko@0.15.4-r1?arch=x86_64"}}}
+
+	doc := &vex.VEX{
+		Statements: []vex.Statement{
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2023-45288"},
+				Products:      ko,
+				Status:        vex.StatusFixed,
+				Timestamp:     &older,
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2024-24789"},
+				Products:      ko,
+				Status:        vex.StatusNotAffected,
+				Timestamp:     &older,
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2024-24789"},
+				Products:      ko,
+				Status:        vex.StatusAffected,
+				Timestamp:     &newer,
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "GHSA-w32m-9786-jp63"},
+				Products:      ko,
+				Status:        vex.StatusNotAffected,
+				Timestamp:     &older,
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2024-45338"},
+				Products:      ko,
+				Status:        vex.StatusAffected,
+				Timestamp:     &newer,
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2024-24791"},
+				Products:      koX8664,
+				Status:        vex.StatusNotAffected,
+				Timestamp:     &older,
+			},
+		},
+	}
+
+	results := []scan.Result{
+		{
+			TargetAPK: scan.TargetAPK{Name: "ko", Version: "0.15.4-r1"},
+			Findings: []scan.Finding{
+				{
+					// Covered via an alias.
+					Vulnerability: scan.Vulnerability{ID: "GHSA-4v7x-pqxf-cx7m", Aliases: []string{"CVE-2023-45288"}},
+				},
+				{
+					// Same vulnerability as above, found in another component under its
+					// CVE ID. It's only counted once.
+					Package:       scan.Package{Name: "stdlib"},
+					Vulnerability: scan.Vulnerability{ID: "CVE-2023-45288"},
+				},
+				{
+					// Latest statement is affected.
+					Vulnerability: scan.Vulnerability{ID: "CVE-2024-24789"},
+				},
+				{
+					// The older statement under the alias is not_affected, but the newer
+					// statement under the ID is affected.
+					Vulnerability: scan.Vulnerability{ID: "CVE-2024-45338", Aliases: []string{"GHSA-w32m-9786-jp63"}},
+				},
+				{
+					// Covered by a statement whose product has qualifiers.
+					Vulnerability: scan.Vulnerability{ID: "CVE-2024-24791"},
+				},
+				{
+					// No statement at all.
+					Vulnerability: scan.Vulnerability{ID: "CVE-2024-24790"},
+				},
+				{
+					// Same vulnerability as above, found in another component. It's
+					// only counted once.
+					Package:       scan.Package{Name: "stdlib"},
+					Vulnerability: scan.Vulnerability{ID: "CVE-2024-24790"},
+				},
+			},
+		},
+		{
+			// The statements are for a different version of ko.
+			TargetAPK: scan.TargetAPK{Name: "ko", Version: "0.15.2-r0"},
+			Findings: []scan.Finding{
+				{
+					Vulnerability: scan.Vulnerability{ID: "CVE-2023-45288"},
+				},
+			},
+		},
+	}
+
+	expected := Coverage{
+		Covered: 2,
+		Uncovered: []UncoveredVulnerability{
+			{
+				PURL:          "pkg:apk/wolfi/ko@0.15.2-r0",
+				Vulnerability: scan.Vulnerability{ID: "CVE-2023-45288"},
+			},
+			{
+				PURL:          "pkg:apk/wolfi/ko@0.15.4-r1",
+				Vulnerability: scan.Vulnerability{ID: "CVE-2024-24789"},
+			},
+			{
+				PURL:          "pkg:apk/wolfi/ko@0.15.4-r1",
+				Vulnerability: scan.Vulnerability{ID: "CVE-2024-24790"},
+			},
+			{
+				PURL:          "pkg:apk/wolfi/ko@0.15.4-r1",
+				Vulnerability: scan.Vulnerability{ID: "CVE-2024-45338", Aliases: []string{"GHSA-w32m-9786-jp63"}},
+			},
+		},
+	}
+
+	got := CoverageOf(doc, results, "wolfi")
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("CoverageOf() mismatch (-want +got):\n%s", diff)
+	}
+}
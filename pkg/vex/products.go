@@ -0,0 +1,84 @@
+package vex
+
+import (
+	"slices"
+
+	"chainguard.dev/apko/pkg/apk/apk"
+	"github.com/openvex/go-vex/pkg/vex"
+	"github.com/package-url/packageurl-go"
+)
+
+// ProductPURLs returns the purls of all products cited by the document's
+// statements. The list is sorted and has no duplicates. Purls are returned in
+// canonical form, so two purls that differ only in the order of their
+// qualifiers are listed once.
+func ProductPURLs(doc *vex.VEX) []string {
+	var purls []string
+
+	for i := range doc.Statements {
+		for _, product := range doc.Statements[i].Products {
+			if purl := productPURL(product.Component); purl != "" {
+				purls = append(purls, purl)
+			}
+		}
+	}
+
+	slices.Sort(purls)
+	return slices.Compact(purls)
+}
+
+// productPURL returns the canonical form of the purl that identifies the
+// component, or an empty string if the component isn't identified by a purl.
+func productPURL(c vex.Component) string {
+	if purl, ok := c.Identifiers[vex.PURL]; ok {
+		return canonicalPURL(purl)
+	}
+
+	if c.ID != "" {
+		if p, err := packageurl.FromString(c.ID); err == nil {
+			return p.ToString()
+		}
+	}
+
+	return ""
+}
+
+// canonicalPURL returns the purl with its qualifiers sorted by key. If the purl
+// can't be parsed, it's returned unchanged.
+func canonicalPURL(purl string) string {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return purl
+	}
+
+	return p.ToString()
+}
+
+// UnresolvedProducts returns the purls of the document's products that don't
+// correspond to a package name and version in any of the given APKINDEXes of
+// the distro. Products that aren't apk packages of the distro (i.e. whose purl
+// namespace isn't the distro) can't resolve to an APKINDEX entry, so they're
+// always returned.
+func UnresolvedProducts(doc *vex.VEX, distro string, indexes ...*apk.APKIndex) []string {
+	versionsByName := make(map[string][]string)
+	for _, index := range indexes {
+		for _, pkg := range index.Packages {
+			versionsByName[pkg.Name] = append(versionsByName[pkg.Name], pkg.Version)
+		}
+	}
+
+	var unresolved []string
+	for _, purl := range ProductPURLs(doc) {
+		p, err := packageurl.FromString(purl)
+		if err != nil || p.Type != packageurl.TypeApk || p.Namespace != distro {
+			unresolved = append(unresolved, purl)
+			continue
+		}
+
+		if !slices.Contains(versionsByName[p.Name], p.Version) {
+			unresolved = append(unresolved, purl)
+		}
+	}
+
+	return unresolved
+}
@@ -0,0 +1,82 @@
+package vex
+
+import (
+	"testing"
+
+	"chainguard.dev/apko/pkg/apk/apk"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openvex/go-vex/pkg/vex"
+)
+
+func TestUnresolvedProducts(t *testing.T) {
+	index := &apk.APKIndex{
+		Packages: []*apk.Package{
+			{Name: "curl", Version: "8.4.0-r0"},
+			{Name: "curl", Version: "8.4.0-r1"},
+			{Name: "libcurl4", Version: "8.4.0-r1"},
+		},
+	}
+
+	doc := &vex.VEX{
+		Statements: []vex.Statement{
+			{
+				Products: []vex.Product{
+					{Component: vex.Component{ID: "pkg:apk/wolfi/curl@8.4.0-r0"}},
+					{Component: vex.Component{ID: "pkg:apk/wolfi/curl@8.3.0-r0"}},
+				},
+			},
+			{
+				Products: []vex.Product{
+					{Component: vex.Component{Identifiers: map[vex.IdentifierType]string{vex.PURL: "pkg:apk/wolfi/libcurl4@8.4.0-r1"}}},
+					{Component: vex.Component{ID: "pkg:apk/wolfi/libcurl-openssl4@8.4.0-r1"}},
+					{Component: vex.Component{ID: "pkg:oci/curl@sha256%3A0123456789abcdef"}},
+					{Component: vex.Component{ID: "pkg:apk/wolfi/curl@8.4.0-r0"}},
+					{Component: vex.Component{ID: "pkg:apk/alpine/curl@8.4.0-r1"}},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"pkg:apk/alpine/curl@8.4.0-r1",
+		"pkg:apk/wolfi/curl@8.3.0-r0",
+		"pkg:apk/wolfi/libcurl-openssl4@8.4.0-r1",
+		"pkg:oci/curl@sha256%3A0123456789abcdef",
+	}
+
+	got := UnresolvedProducts(doc, "wolfi", index)
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("UnresolvedProducts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProductPURLs(t *testing.T) {
+	doc := &vex.VEX{
+		Statements: []vex.Statement{
+			{
+				Products: []vex.Product{
+					{Component: vex.Component{ID: "pkg:apk/wolfi/curl@8.4.0-r0?arch=x86_64&distro=wolfi"}},
+					{Component: vex.Component{ID: "https://example.com/not-a-purl"}},
+				},
+			},
+			{
+				Products: []vex.Product{
+					{Component: vex.Component{ID: "pkg:apk/wolfi/curl@8.4.0-r0?distro=wolfi&arch=x86_64"}},
+					{Component: vex.Component{Identifiers: map[vex.IdentifierType]string{vex.PURL: "pkg:apk/wolfi/zlib@1.3-r0?distro=wolfi&arch=aarch64"}}},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"pkg:apk/wolfi/curl@8.4.0-r0?arch=x86_64&distro=wolfi",
+		"pkg:apk/wolfi/zlib@1.3-r0?arch=aarch64&distro=wolfi",
+	}
+
+	got := ProductPURLs(doc)
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("ProductPURLs() mismatch (-want +got):\n%s", diff)
+	}
+}
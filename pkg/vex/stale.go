@@ -0,0 +1,33 @@
+package vex
+
+import (
+	"time"
+
+	"github.com/openvex/go-vex/pkg/vex"
+)
+
+// StaleInvestigations returns the document's under_investigation statements
+// that are more than maxAge older than now. Statements without their own
+// timestamp use the document's timestamp; statements with no timestamp at all
+// can't be aged, so they're not returned.
+func StaleInvestigations(doc *vex.VEX, now time.Time, maxAge time.Duration) []vex.Statement {
+	var stale []vex.Statement
+
+	for i := range doc.Statements {
+		s := doc.Statements[i]
+		if s.Status != vex.StatusUnderInvestigation {
+			continue
+		}
+
+		t := StatementTime(doc, s)
+		if t.IsZero() {
+			continue
+		}
+
+		if now.Sub(t) > maxAge {
+			stale = append(stale, s)
+		}
+	}
+
+	return stale
+}
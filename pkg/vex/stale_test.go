@@ -0,0 +1,66 @@
+package vex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openvex/go-vex/pkg/vex"
+)
+
+func TestStaleInvestigations(t *testing.T) {
+	now := time.Date(2023, time.November, 30, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+
+	doc := &vex.VEX{
+		Metadata: vex.Metadata{Timestamp: daysAgo(45)},
+		Statements: []vex.Statement{
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2023-0001"},
+				Status:        vex.StatusUnderInvestigation,
+				Timestamp:     daysAgo(31),
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2023-0002"},
+				Status:        vex.StatusUnderInvestigation,
+				Timestamp:     daysAgo(29),
+			},
+			{
+				Vulnerability: vex.Vulnerability{Name: "CVE-2023-0003"},
+				Status:        vex.StatusAffected,
+				Timestamp:     daysAgo(60),
+			},
+			{
+				// No statement timestamp, so the document's timestamp applies.
+				Vulnerability: vex.Vulnerability{Name: "CVE-2023-0004"},
+				Status:        vex.StatusUnderInvestigation,
+			},
+			{
+				// A zero statement timestamp is treated as unset.
+				Vulnerability: vex.Vulnerability{Name: "CVE-2023-0005"},
+				Status:        vex.StatusUnderInvestigation,
+				Timestamp:     &time.Time{},
+			},
+		},
+	}
+
+	got := StaleInvestigations(doc, now, 30*24*time.Hour)
+
+	var gotIDs []vex.VulnerabilityID
+	for _, s := range got {
+		gotIDs = append(gotIDs, s.Vulnerability.Name)
+	}
+
+	expected := []vex.VulnerabilityID{"CVE-2023-0001", "CVE-2023-0004", "CVE-2023-0005"}
+	if diff := cmp.Diff(expected, gotIDs); diff != "" {
+		t.Errorf("StaleInvestigations() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The time reported for a stale statement is the one it was aged by.
+	if got, want := StatementTime(doc, got[2]), *doc.Timestamp; !got.Equal(want) {
+		t.Errorf("StatementTime() = %s, want %s", got, want)
+	}
+}